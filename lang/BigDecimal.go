@@ -0,0 +1,28 @@
+package lang
+
+import "math/big"
+
+// BigDecimal backs Clojure's arbitrary-precision decimal literals (`3.14M`).
+type BigDecimal struct {
+	val *big.Float
+}
+
+func CreateBigDecimal(v *big.Float) *BigDecimal {
+	return &BigDecimal{val: v}
+}
+
+func (b *BigDecimal) String() string {
+	return b.val.Text('f', -1)
+}
+
+func (b *BigDecimal) Value() *big.Float {
+	return b.val
+}
+
+func (b *BigDecimal) Equals(o interface{}) bool {
+	other, ok := o.(*BigDecimal)
+	if !ok {
+		return false
+	}
+	return b.val.Cmp(other.val) == 0
+}
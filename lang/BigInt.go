@@ -0,0 +1,29 @@
+package lang
+
+import "math/big"
+
+// BigInt backs Clojure's arbitrary-precision integer literals (`123N`) and
+// integer literals too large for an int64.
+type BigInt struct {
+	val *big.Int
+}
+
+func CreateBigInt(v *big.Int) *BigInt {
+	return &BigInt{val: v}
+}
+
+func (b *BigInt) String() string {
+	return b.val.String()
+}
+
+func (b *BigInt) Value() *big.Int {
+	return b.val
+}
+
+func (b *BigInt) Equals(o interface{}) bool {
+	other, ok := o.(*BigInt)
+	if !ok {
+		return false
+	}
+	return b.val.Cmp(other.val) == 0
+}
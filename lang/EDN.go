@@ -0,0 +1,139 @@
+package lang
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// clojure.edn/read-ish options, used only by ReadEDN/ReadEDNString.
+var OPT_READERS *Keyword = InternKeywordByNsName("readers")
+var OPT_DEFAULT_DATA_READER_FN_OPT *Keyword = InternKeywordByNsName("default-data-reader-fn")
+
+// ednDispatchMacros is dispatchMacros with '=' replaced by a reader that
+// always refuses to read - edn never evaluates code.
+var ednDispatchMacros map[rune]IFn
+
+func init() {
+	ednDispatchMacros = make(map[rune]IFn, len(dispatchMacros))
+	for ch, fn := range dispatchMacros {
+		ednDispatchMacros[ch] = fn
+	}
+	ednDispatchMacros['='] = &disallowedEvalReader{}
+}
+
+type disallowedEvalReader struct {
+	AFn
+}
+
+func (er *disallowedEvalReader) Invoke(args ...interface{}) interface{} {
+	panic("#= is not allowed when reading EDN")
+}
+
+// ednCtorReader is CtorReader restricted to the built-in #inst/#uuid readers
+// and the :readers map passed in opts - it never consults the global
+// *data-readers* var. The built-in readers are tried before :readers.
+type ednCtorReader struct {
+	AFn
+}
+
+func (er *ednCtorReader) Invoke(args ...interface{}) interface{} {
+	r, _, opts, pendingForms := unpackReaderArgs(args)
+	pendingForms = r.ensurePending(pendingForms)
+
+	// DispatchReader already unread the dispatch rune before invoking us,
+	// so read it fresh here rather than reusing the stale rune it passed
+	// in -- otherwise ReadToken would see that same rune twice and double
+	// it into the tag (see CtorReader.Invoke for the same fix).
+	ch, err := r.ReadRune()
+	if err == io.EOF {
+		panic(r.eofMsg("character"))
+	}
+
+	token := r.ReadToken(ch)
+	tag, ok := matchSymbol(token).(*Symbol)
+	if !ok {
+		panic("Reader tag must be a symbol: " + token)
+	}
+
+	if tag.ns == "" && strings.Contains(tag.name, ".") {
+		panic("no ctor reader for class: " + token)
+	}
+
+	form := r.Read(true, nil, rune(0), nil, true, opts, pendingForms)
+
+	if fn, ok := defaultDataReaders[tag]; ok {
+		return fn.Invoke(form)
+	}
+
+	if readers, ok := readerOpt(opts, OPT_READERS).(IPersistentMap); ok && readers != nil {
+		if fn, ok := readers.ValAt(tag).(IFn); ok && fn != nil {
+			return fn.Invoke(form)
+		}
+	}
+
+	if defaultFn, ok := readerOpt(opts, OPT_DEFAULT_DATA_READER_FN_OPT).(IFn); ok && defaultFn != nil {
+		return defaultFn.Invoke(tag, form)
+	}
+
+	return CreateTaggedLiteral(tag, form)
+}
+
+// ReaderError is returned by ReadEDN/ReadEDNString instead of panicking, so
+// that untrusted input can be read safely.
+type ReaderError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (re *ReaderError) Error() string {
+	if re.Line < 0 {
+		return re.Message
+	}
+	return fmt.Sprintf("%s, line %d, column %d", re.Message, re.Line, re.Column)
+}
+
+func ednOptsToMap(opts map[*Keyword]interface{}) interface{} {
+	pairs := make([]interface{}, 0, len(opts)*2)
+	for k, v := range opts {
+		pairs = append(pairs, k, v)
+	}
+	return RT.Map(pairs...)
+}
+
+// ReadEDN reads a single EDN value from r. Unlike LispReader.Read, it never
+// evaluates code (#= is disabled, *read-eval* is forced off), only honors
+// the built-in #inst/#uuid readers and tagged-literal readers supplied
+// explicitly via the :readers option, and only selects reader-conditional
+// branches when the caller opts in with :read-cond :allow (and :features);
+// without :read-cond, a reader conditional is skipped. Any error - malformed
+// syntax, EOF, an attempt to use #= - is returned as a *ReaderError rather
+// than a panic.
+func ReadEDN(r io.Reader, opts map[*Keyword]interface{}) (result interface{}, err error) {
+	lr := &LispReader{
+		r:              CreateLineNumberingPushbackReader(r),
+		macros:         macros,
+		dispatchMacros: ednDispatchMacros,
+		ctor:           &ednCtorReader{},
+	}
+
+	PushThreadBindings(RT.Map(READEVAL, false))
+	defer PopThreadBindings()
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			line, column := lr.LineColumn()
+			result = nil
+			err = &ReaderError{Line: line, Column: column, Message: fmt.Sprintf("%v", rec)}
+		}
+	}()
+
+	result = lr.Read(true, nil, rune(0), nil, false, ednOptsToMap(opts), nil)
+	return result, nil
+}
+
+// ReadEDNString is ReadEDN over a string.
+func ReadEDNString(s string, opts map[*Keyword]interface{}) (interface{}, error) {
+	return ReadEDN(strings.NewReader(s), opts)
+}
@@ -0,0 +1,50 @@
+package lang
+
+import "testing"
+
+func TestReadEDNString(t *testing.T) {
+	t.Run("reads a plain value", func(t *testing.T) {
+		got, err := ReadEDNString("[1 2 3]", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		items := seqSlice(got.(Seqable).Seq())
+		if len(items) != 3 || items[0] != 1 || items[1] != 2 || items[2] != 3 {
+			t.Fatalf("got %#v, want [1 2 3]", items)
+		}
+	})
+
+	t.Run("honors the built-in #inst/#uuid readers", func(t *testing.T) {
+		got, err := ReadEDNString(`#uuid "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"`, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := got.(*UUID); !ok {
+			t.Fatalf("expected *UUID, got %#v", got)
+		}
+	})
+
+	t.Run("#= is disallowed and reported as a *ReaderError, not a panic", func(t *testing.T) {
+		_, err := ReadEDNString(`#=(+ 1 2)`, nil)
+		if err == nil {
+			t.Fatalf("expected an error for #= in EDN")
+		}
+		if _, ok := err.(*ReaderError); !ok {
+			t.Fatalf("expected *ReaderError, got %#v", err)
+		}
+	})
+
+	t.Run("malformed input is reported as a *ReaderError with position", func(t *testing.T) {
+		_, err := ReadEDNString(`(1 2`, nil)
+		if err == nil {
+			t.Fatalf("expected an error for unterminated input")
+		}
+		re, ok := err.(*ReaderError)
+		if !ok {
+			t.Fatalf("expected *ReaderError, got %#v", err)
+		}
+		if re.Line < 0 {
+			t.Fatalf("expected a line/column to be recorded, got %#v", re)
+		}
+	})
+}
@@ -7,10 +7,12 @@ import (
 	"regexp"
 	"unicode"
 	"container/list"
+	"math/big"
 	"math/rand"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 /*
@@ -19,8 +21,10 @@ import (
 
 var QUOTE *Symbol = InternSymbol("quote")
 var THE_VAR *Symbol = InternSymbol("var")
+var FN_STAR *Symbol = InternSymbol("fn*")
+var AMP *Symbol = InternSymbol("&")
 var UNQUOTE *Symbol = InternSymbol("clojure.core", "unquote")
-var UNQUOTE_SPLICING *Symbol = InternSymbol("clojure.core", "unqoute-splicing")
+var UNQUOTE_SPLICING *Symbol = InternSymbol("clojure.core", "unquote-splicing")
 var CONCAT *Symbol = InternSymbol("clojure.core", "concat")
 var SEQ *Symbol = InternSymbol("clojure.core", "seq")
 var LIST *Symbol = InternSymbol("clojure.core", "list")
@@ -69,15 +73,36 @@ var dispatchMacros map[rune]IFn = map[rune]IFn{
 }
 
 var symbolPat *regexp.Regexp = regexp.MustCompile(`:?([^/\d].*/)?(/|[^\d/][^/]*)`)
-var intPat *regexp.Regexp = regexp.MustCompile(`([-+]?)(?:(0)|([1-9][0-9]*)|0[xX]([0-9A-Fa-f]+)|0([0-7]+)|([1-9][0-9]?)[rR]([0-9A-Za-z]+)|0[0-9]+)(N)?`)
-var radioPat *regexp.Regexp = regexp.MustCompile(`([-+]?[0-9]+)/([0-9]+)`)
-var floatPat *regexp.Regexp = regexp.MustCompile("([-+]?[0-9]+(\\.[0-9]*)?([eE][-+]?[0-9]+)?)(M)?")
+// These are anchored with ^...$ so that, e.g., an input starting with "0"
+// (like "0x1F") is forced all the way through to the hex/octal/radix
+// alternatives instead of matching just the leading "(0)" alternative and
+// leaving the rest of the string unconsumed -- Go's RE2 alternation is
+// leftmost-first, not longest-match, so without the anchors it happily
+// settles for the first alternative that matches a prefix.
+var intPat *regexp.Regexp = regexp.MustCompile(`^([-+]?)(?:(0)|([1-9][0-9]*)|0[xX]([0-9A-Fa-f]+)|0([0-7]+)|([1-9][0-9]?)[rR]([0-9A-Za-z]+)|0[0-9]+)(N)?$`)
+var radioPat *regexp.Regexp = regexp.MustCompile(`^([-+]?[0-9]+)/([0-9]+)$`)
+var floatPat *regexp.Regexp = regexp.MustCompile("^([-+]?[0-9]+(\\.[0-9]*)?([eE][-+]?[0-9]+)?)(M)?$")
 
 var GENSYM_ENV *Var = CreateVarFromNothing().SetDynamic()
 var ARG_ENV *Var = CreateVarFromNothing().SetDynamic()
 var ctorReader IFn = &CtorReader{}
 
-var READ_COND_ENV *Var = CreateVarFromNothing().SetDynamic()
+// *data-readers* and *default-data-reader-fn*, consulted by CtorReader for
+// tagged literals (`#my/tag form`) that aren't one of the built-ins below.
+var DATA_READERS *Var = CreateVarFromNothing().SetDynamic()
+var DEFAULT_DATA_READER_FN *Var = CreateVarFromNothing().SetDynamic()
+
+var TAG_KEY *Keyword = InternKeywordByNsName("tag")
+var FORM_KEY *Keyword = InternKeywordByNsName("form")
+
+var INST_READER_SYM *Symbol = InternSymbol("inst")
+var UUID_READER_SYM *Symbol = InternSymbol("uuid")
+
+// defaultDataReaders are always available, regardless of *data-readers*.
+var defaultDataReaders = map[*Symbol]IFn{
+	INST_READER_SYM: &instReader{},
+	UUID_READER_SYM: &uuidReader{},
+}
 
 // Reader opts
 var OPT_EOF *Keyword = InternKeywordByNsName("eof")
@@ -95,6 +120,9 @@ var PLATFORM_FEATURES interface{} = CreatePersistentHashSetFromInterfaceSlice(PL
 var COND_ALLOW *Keyword = InternKeywordByNsName("allow")
 var COND_PRESERVE *Keyword = InternKeywordByNsName("preserve")
 
+// The catch-all branch in a reader conditional
+var DEFAULT_FEATURE_KEY *Keyword = InternKeywordByNsName("default")
+
 // These are sentinel values.
 var READ_EOF = rand.Int()
 var READ_FINISHED = rand.Int()
@@ -103,6 +131,105 @@ var READ_FINISHED = rand.Int()
 
 // TODO: A large block of code here
 
+/*
+	PushbackReader is the minimal surface LispReader needs from its underlying
+	rune source. A plain *bufio.Reader satisfies it, as does
+	*LineNumberingPushbackReader, which additionally tracks line/column.
+*/
+type PushbackReader interface {
+	ReadRune() (rune, int, error)
+	UnreadRune() error
+}
+
+/*
+	LineNumberingPushbackReader wraps a bufio.Reader and tracks the current
+	line and column (both 1-based), along with a small stack of recently read
+	runes so that UnreadRune restores the exact line/column that was current
+	before the rune was read, including across '\n'.
+*/
+type lnprMark struct {
+	ch           rune
+	size         int
+	beforeLine   int
+	beforeColumn int
+}
+
+type LineNumberingPushbackReader struct {
+	r      *bufio.Reader
+	line   int
+	column int
+
+	read   []lnprMark // runes read so far that are still eligible to be unread
+	unread []lnprMark // runes pushed back, replayed on the next ReadRune
+
+	// lastReadOk is true only right after a ReadRune call that actually
+	// returned a rune. UnreadRune refuses to run otherwise, mirroring
+	// bufio.Reader's ErrInvalidUnreadRune -- without this, a failed
+	// ReadRune (real EOF) followed by UnreadRune would silently resurrect
+	// the previous rune forever instead of ever reporting EOF.
+	lastReadOk bool
+}
+
+func CreateLineNumberingPushbackReader(r io.Reader) *LineNumberingPushbackReader {
+	return &LineNumberingPushbackReader{
+		r:      bufio.NewReader(r),
+		line:   1,
+		column: 1,
+	}
+}
+
+func (lnpr *LineNumberingPushbackReader) ReadRune() (rune, int, error) {
+	var m lnprMark
+	if n := len(lnpr.unread); n > 0 {
+		m = lnpr.unread[n-1]
+		lnpr.unread = lnpr.unread[:n-1]
+	} else {
+		ch, size, err := lnpr.r.ReadRune()
+		if err != nil {
+			lnpr.lastReadOk = false
+			return ch, size, err
+		}
+		m = lnprMark{ch: ch, size: size, beforeLine: lnpr.line, beforeColumn: lnpr.column}
+	}
+
+	if m.ch == '\n' {
+		lnpr.line++
+		lnpr.column = 1
+	} else {
+		lnpr.column++
+	}
+	// Only the most recently read rune can be unread (see lastReadOk), so
+	// older entries are dropped rather than kept for the whole stream.
+	lnpr.read = append(lnpr.read[:0], m)
+	lnpr.lastReadOk = true
+	return m.ch, m.size, nil
+}
+
+func (lnpr *LineNumberingPushbackReader) UnreadRune() error {
+	if !lnpr.lastReadOk {
+		return bufio.ErrInvalidUnreadRune
+	}
+	n := len(lnpr.read)
+	if n == 0 {
+		return fmt.Errorf("LineNumberingPushbackReader: nothing to unread")
+	}
+	m := lnpr.read[n-1]
+	lnpr.read = lnpr.read[:n-1]
+	lnpr.line = m.beforeLine
+	lnpr.column = m.beforeColumn
+	lnpr.unread = append(lnpr.unread, m)
+	lnpr.lastReadOk = false
+	return nil
+}
+
+func (lnpr *LineNumberingPushbackReader) Line() int {
+	return lnpr.line
+}
+
+func (lnpr *LineNumberingPushbackReader) Column() int {
+	return lnpr.column
+}
+
 /*
 	LispReader
 
@@ -111,7 +238,13 @@ var READ_FINISHED = rand.Int()
 */
 
 type LispReader struct {
-	r *bufio.Reader
+	r PushbackReader
+
+	// macros, dispatchMacros and ctor let a reader use a restricted dispatch
+	// table (see ReadEDN) instead of the full, global one.
+	macros         map[rune]IFn
+	dispatchMacros map[rune]IFn
+	ctor           IFn
 }
 
 func (lr *LispReader) ReadRune() (rune, error) {
@@ -132,13 +265,35 @@ func (lr *LispReader) UnreadRune() {
 	}
 }
 
+// LineColumn reports the reader's current (line, column), or (-1, -1) if the
+// underlying reader doesn't track position.
+func (lr *LispReader) LineColumn() (int, int) {
+	if lnpr, ok := lr.r.(*LineNumberingPushbackReader); ok {
+		return lnpr.Line(), lnpr.Column()
+	}
+	return -1, -1
+}
+
 // TODO: make this private in the future?
 func CreateLispReader(r io.Reader) *LispReader {
 	return &LispReader{
-		r: bufio.NewReader(r),
+		r:              CreateLineNumberingPushbackReader(r),
+		macros:         macros,
+		dispatchMacros: dispatchMacros,
+		ctor:           ctorReader,
 	}
 }
 
+// eofMsg formats an "EOF while reading ..." panic message, including the
+// reader's current line/column when it's tracking position.
+func (lr *LispReader) eofMsg(what string) string {
+	line, column := lr.LineColumn()
+	if line < 0 {
+		return "EOF while reading " + what
+	}
+	return fmt.Sprintf("EOF while reading %s, line %d, column %d", what, line, column)
+}
+
 func (lr *LispReader) ensurePending(pendingForms interface{}) interface{} {
 	if pendingForms == nil {
 		return list.New()
@@ -153,7 +308,10 @@ func (lr *LispReader) ReadToken(initch rune) string {
 
 	for {
 		ch, err := lr.ReadRune()
-		if err != nil || unicode.IsSpace(ch) || lr.IsTerminatingMacro(ch) {
+		if err != nil {
+			return b.String()
+		}
+		if unicode.IsSpace(ch) || lr.IsTerminatingMacro(ch) {
 			lr.UnreadRune()
 			return b.String()
 		}
@@ -161,37 +319,139 @@ func (lr *LispReader) ReadToken(initch rune) string {
 	}
 }
 
-// TODO
 func (lr *LispReader) ReadNumber(initch rune) interface{} {
 	var sb bytes.Buffer
 	sb.WriteRune(initch)
 	for {
 		ch, err := lr.ReadRune()
-		if err != nil || unicode.IsSpace(ch) || lr.IsMacro(ch) {
+		if err != nil {
+			break
+		}
+		if unicode.IsSpace(ch) || lr.IsMacro(ch) {
 			lr.UnreadRune()
 			break
 		}
-		sb.WriteRune(initch)
+		sb.WriteRune(ch)
 	}
+
 	s := sb.String()
-	n, interr := strconv.ParseInt(s, 10, 64)
-	f, flerr := strconv.ParseFloat(s, 64)
+	if n := matchNumber(s); n != nil {
+		return n
+	}
+	panic("Invalid number: " + s)
+}
 
-	if interr != nil && flerr != nil {
-		panic(fmt.Sprintf("Invalid number: %v", s))
+// matchNumber parses the full Clojure numeric grammar: signed decimals, hex
+// (0x...), octal (0...), arbitrary-radix (NrDDD, 2<=N<=36), ratios (n/d),
+// and floats with an optional exponent. Integers take an optional trailing N
+// (BigInt) and floats an optional trailing M (BigDecimal); ratios take no
+// suffix. Returns nil if s matches none of these patterns, and panics if it
+// matches one but cannot be parsed (e.g. "08").
+func matchNumber(s string) interface{} {
+	if m := intPat.FindStringSubmatch(s); m != nil && m[0] == s {
+		return parseMatchedInt(s, m)
 	}
-	if interr != nil {
-		return f
-	} else {
-		return int(n)
+	if m := floatPat.FindStringSubmatch(s); m != nil && m[0] == s {
+		return parseMatchedFloat(s, m)
+	}
+	if m := radioPat.FindStringSubmatch(s); m != nil && m[0] == s {
+		return parseMatchedRatio(s, m)
+	}
+	return nil
+}
+
+func parseMatchedInt(s string, m []string) interface{} {
+	var value *big.Int
+
+	switch {
+	case m[2] == "0" && m[3] == "" && m[4] == "" && m[5] == "" && m[7] == "":
+		value = big.NewInt(0)
+	case m[3] != "":
+		v, ok := new(big.Int).SetString(m[3], 10)
+		if !ok {
+			panic("Invalid number: " + s)
+		}
+		value = v
+	case m[4] != "":
+		v, ok := new(big.Int).SetString(m[4], 16)
+		if !ok {
+			panic("Invalid number: " + s)
+		}
+		value = v
+	case m[5] != "":
+		v, ok := new(big.Int).SetString(m[5], 8)
+		if !ok {
+			panic("Invalid number: " + s)
+		}
+		value = v
+	case m[6] != "" && m[7] != "":
+		radix, err := strconv.Atoi(m[6])
+		if err != nil || radix < 2 || radix > 36 {
+			panic("Invalid number: " + s)
+		}
+		v, ok := new(big.Int).SetString(m[7], radix)
+		if !ok {
+			panic("Invalid number: " + s)
+		}
+		value = v
+	default:
+		panic("Invalid number: " + s)
+	}
+
+	if m[1] == "-" {
+		value = new(big.Int).Neg(value)
+	}
+
+	if m[8] == "N" {
+		return CreateBigInt(value)
+	}
+	if value.IsInt64() {
+		return int(value.Int64())
+	}
+	return CreateBigInt(value)
+}
+
+func parseMatchedFloat(s string, m []string) interface{} {
+	if m[4] == "M" {
+		f, _, err := big.ParseFloat(m[1], 10, 256, big.ToNearestEven)
+		if err != nil {
+			panic("Invalid number: " + s)
+		}
+		return CreateBigDecimal(f)
+	}
+	f, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		panic("Invalid number: " + s)
 	}
+	return f
+}
+
+func parseMatchedRatio(s string, m []string) interface{} {
+	num, ok := new(big.Int).SetString(m[1], 10)
+	if !ok {
+		panic("Invalid number: " + s)
+	}
+	den, ok := new(big.Int).SetString(m[2], 10)
+	if !ok || den.Sign() == 0 {
+		panic("Invalid number: " + s)
+	}
+
+	rat := new(big.Rat).SetFrac(num, den)
+	if rat.IsInt() {
+		v := rat.Num()
+		if v.IsInt64() {
+			return int(v.Int64())
+		}
+		return CreateBigInt(v)
+	}
+	return CreateRatio(rat)
 }
 
 // TODO....there's other functions in here
 
 func (lr *LispReader) IsMacro(ch rune) bool {
 	// NOTE: This behaves a little differently in the Java version, due to note using a map for `macros`.
-	return macros[ch] != nil
+	return lr.macros[ch] != nil
 }
 
 func (lr *LispReader) IsTerminatingMacro(ch rune) bool {
@@ -199,19 +459,17 @@ func (lr *LispReader) IsTerminatingMacro(ch rune) bool {
 }
 
 func (lr *LispReader) ReadDelimitedList(delim rune, isRecursive bool, opts interface{}, pendingForms interface{}) []interface{} {
-	// NOTE: There's some code here that checks to see if the reader is a LineNumberingPushbackReader.
-	// We don't have such a thing in Go yet but I might create one in the future.
-	firstline := -1
+	firstLine, firstColumn := lr.LineColumn()
 
 	a := make([]interface{}, 0)
 	for {
 		form := lr.Read(false, READ_EOF, delim, READ_FINISHED, isRecursive, opts, pendingForms)
 
 		if form == READ_EOF {
-			if firstline < 0 {
+			if firstLine < 0 {
 				panic("EOF while reading")
 			} else {
-				panic("EOF while reading, starting at line " + string(firstline))
+				panic(fmt.Sprintf("EOF while reading, starting at line %d, column %d", firstLine, firstColumn))
 			}
 		} else if form == READ_FINISHED {
 			return a
@@ -226,12 +484,12 @@ func (lr *LispReader) Read(eofIsError bool, eofValue interface{}, returnOn rune,
 		panic("Reading disallowed - *read-eval* bound to :unknown")
 	}
 
-	// TODO: opts = installPlatformFeature(opts)
+	opts = installPlatformFeature(opts)
 
 	for {
 		switch pf := pendingForms.(type) {
-		case list.List:
-			if !(pf.Len() == 0) {
+		case *list.List:
+			if pf.Len() != 0 {
 				return pf.Remove(pf.Front())
 			}
 		}
@@ -244,7 +502,7 @@ func (lr *LispReader) Read(eofIsError bool, eofValue interface{}, returnOn rune,
 
 		if err == io.EOF {
 			if eofIsError {
-				panic("EOF while reading")
+				panic(lr.eofMsg("form"))
 			}
 			return eofValue
 		}
@@ -258,26 +516,31 @@ func (lr *LispReader) Read(eofIsError bool, eofValue interface{}, returnOn rune,
 			return n
 		}
 
-		var macroFn IFn = macros[ch]
+		var macroFn IFn = lr.macros[ch]
 		if macroFn != nil {
 
 			ret := macroFn.Invoke(lr, ch, opts, pendingForms)
 
-			// NOTE: This doesn't make sense to me.
-			if ret == lr.r {
+			// Readers that only advance the stream without producing a value
+			// (DiscardReader, and ConditionalReader's discard/no-match/
+			// splicing paths) signal "keep reading" by returning the
+			// *LispReader itself.
+			if ret == lr {
 				continue
 			}
 			return ret
 		}
 
 		if ch == '+' || ch == '-' {
-			ch2, _ := lr.ReadRune()
-			if unicode.IsDigit(ch2) {
+			ch2, err2 := lr.ReadRune()
+			if err2 == nil {
+				if unicode.IsDigit(ch2) {
+					lr.UnreadRune()
+					n := lr.ReadNumber(ch)
+					return n
+				}
 				lr.UnreadRune()
-				n := lr.ReadNumber(ch)
-				return n
 			}
-			lr.UnreadRune()
 		}
 
 		var token string = lr.ReadToken(ch)
@@ -306,13 +569,13 @@ func (rr *RegexReader) Invoke(args ...interface{}) interface{} {
 
 	for ch, err := r.ReadRune(); ch != '"'; ch, err = r.ReadRune() {
 		if err == io.EOF {
-			panic("EOF while reading regex")
+			panic(r.eofMsg("regex"))
 		}
 		sb.WriteRune(ch)
 		if ch == '\\' {
 			ch, err = r.ReadRune()
 			if err == io.EOF {
-				panic("EOF while reading regex")
+				panic(r.eofMsg("regex"))
 			}
 			sb.WriteRune(ch)
 		}
@@ -332,12 +595,12 @@ func (sr *StringReader) Invoke(args ...interface{}) interface{} {
 	for ch, err := r.ReadRune(); ch != '"'; ch, err = r.ReadRune() {
 
 		if err == io.EOF {
-			panic("EOF while reading string")
+			panic(r.eofMsg("string"))
 		}
 		if ch == '\\' {
 			ch, err = r.ReadRune()
 			if err == io.EOF {
-				panic("EOF while reading string")
+				panic(r.eofMsg("string"))
 
 			}
 			switch ch {
@@ -446,13 +709,13 @@ func (dr *DispatchReader) Invoke(args ...interface{}) interface{} {
 	r, _, opts, pendingForms := unpackReaderArgs(args)
 	ch, err := r.ReadRune()
 	if err == io.EOF {
-		panic("EOF while reading character")
+		panic(r.eofMsg("character"))
 	}
-	var fn IFn = dispatchMacros[ch]
+	var fn IFn = r.dispatchMacros[ch]
 	if fn == nil {
 		r.UnreadRune()
 		pendingForms = r.ensurePending(pendingForms)
-		result := ctorReader.Invoke(r, ch, opts, pendingForms)
+		result := r.ctor.Invoke(r, ch, opts, pendingForms)
 
 		if result != nil {
 			return result
@@ -467,18 +730,111 @@ type FnReader struct {
 	AFn
 }
 
-// TODO
+// FnReader implements `#(...)`. DispatchReader has already consumed the
+// '(', so reading the body is exactly ReadDelimitedList(')', ...) like an
+// ordinary list.
 func (fr *FnReader) Invoke(args ...interface{}) interface{} {
-	return nil
+	r, _, opts, pendingForms := unpackReaderArgs(args)
+	pendingForms = r.ensurePending(pendingForms)
+
+	if _, ok := ARG_ENV.Deref().(IPersistentMap); ok {
+		panic("Nested #()s are not allowed")
+	}
+
+	PushThreadBindings(RT.Map(ARG_ENV, RT.Map()))
+	defer PopThreadBindings()
+
+	body := r.ReadDelimitedList(')', true, opts, pendingForms)
+
+	env, _ := ARG_ENV.Deref().(IPersistentMap)
+
+	maxArg := 0
+	if env != nil {
+		for s := env.Seq(); s != nil; s = s.Next() {
+			if entry, ok := s.First().(MapEntry); ok {
+				if n, ok := entry.GetKey().(int); ok && n > maxArg {
+					maxArg = n
+				}
+			}
+		}
+	}
+
+	params := make([]interface{}, 0, maxArg+2)
+	for i := 1; i <= maxArg; i++ {
+		sym, ok := env.ValAt(i).(*Symbol)
+		if !ok || sym == nil {
+			sym = InternSymbol(fmt.Sprintf("p%d__%d__auto__", i, rand.Int()))
+		}
+		params = append(params, sym)
+	}
+	if restSym, ok := env.ValAt(-1).(*Symbol); ok && restSym != nil {
+		params = append(params, AMP, restSym)
+	}
+
+	fnForm := []interface{}{FN_STAR, CreateLazilyPersistentVector(params), CreatePersistentListFromInterfaceSlice(body)}
+	return CreatePersistentListFromInterfaceSlice(fnForm)
 }
 
 type ArgReader struct {
 	AFn
 }
 
-// TODO
+// ArgReader implements `%`, `%N` and `%&` inside `#(...)`, resolving each to
+// (and, on first use, minting) a gensym'd parameter symbol held in ARG_ENV.
 func (ar *ArgReader) Invoke(args ...interface{}) interface{} {
-	return nil
+	r, _, _, _ := unpackReaderArgs(args)
+
+	if _, ok := ARG_ENV.Deref().(IPersistentMap); !ok {
+		// '%' outside of #(...) is just an ordinary symbol character.
+		return interpretToken(r.ReadToken('%'))
+	}
+
+	ch, err := r.ReadRune()
+	if err != nil {
+		return registerArg(1)
+	}
+
+	if unicode.IsSpace(ch) || r.IsTerminatingMacro(ch) {
+		r.UnreadRune()
+		return registerArg(1)
+	}
+
+	if ch == '&' {
+		return registerArg(-1)
+	}
+
+	if unicode.IsDigit(ch) {
+		n := r.ReadNumber(ch)
+		idx, ok := n.(int)
+		if !ok {
+			panic("arg literal must be %, %& or %integer")
+		}
+		return registerArg(idx)
+	}
+
+	panic("arg literal must be %, %& or %integer")
+}
+
+// registerArg looks up (or mints and records) the gensym'd parameter symbol
+// for arg index n (-1 means %&) in the current ARG_ENV binding.
+func registerArg(n int) *Symbol {
+	env, ok := ARG_ENV.Deref().(IPersistentMap)
+	if !ok || env == nil {
+		panic("arg literal not in #()")
+	}
+	if sym, ok := env.ValAt(n).(*Symbol); ok && sym != nil {
+		return sym
+	}
+
+	var name string
+	if n == -1 {
+		name = fmt.Sprintf("rest__%d__auto__", rand.Int())
+	} else {
+		name = fmt.Sprintf("p%d__%d__auto__", n, rand.Int())
+	}
+	sym := InternSymbol(name)
+	ARG_ENV.Set(env.Assoc(n, sym))
+	return sym
 }
 
 type MetaReader struct {
@@ -494,18 +850,168 @@ type SyntaxQuoteReader struct {
 	AFn
 }
 
-// TODO
 func (sr *SyntaxQuoteReader) Invoke(args ...interface{}) interface{} {
-	return nil
+	r, _, opts, pendingForms := unpackReaderArgs(args)
+	pendingForms = r.ensurePending(pendingForms)
+
+	PushThreadBindings(RT.Map(GENSYM_ENV, RT.Map()))
+	defer PopThreadBindings()
+
+	form := r.Read(true, nil, rune(0), nil, true, opts, pendingForms)
+	return syntaxQuote(form)
+}
+
+// syntaxQuote walks a read form and produces the code that, when evaluated,
+// reconstructs it - resolving bare symbols against the current namespace and
+// expanding collections into `(seq (concat ...))`-style builders so that
+// `~`/`~@` can splice into them.
+func syntaxQuote(form interface{}) interface{} {
+	if isUnquote(form) {
+		return second(form)
+	}
+	if isUnquoteSplicing(form) {
+		panic("unquote-splicing (~@) not in list")
+	}
+
+	switch v := form.(type) {
+	case *Symbol:
+		return RT.List(QUOTE, resolveSyntaxQuoteSymbol(v))
+	case *Keyword:
+		return form
+	case IPersistentMap:
+		var flat []interface{}
+		for s := v.Seq(); s != nil; s = s.Next() {
+			if entry, ok := s.First().(MapEntry); ok {
+				flat = append(flat, entry.GetKey(), entry.GetValue())
+			}
+		}
+		return RT.List(APPLY, HASHMAP, RT.List(SEQ, concatForm(sqExpand(flat))))
+	case IPersistentSet:
+		return RT.List(APPLY, HASHSET, RT.List(SEQ, concatForm(sqExpand(seqItems(v)))))
+	case IPersistentVector:
+		return RT.List(APPLY, VECTOR, RT.List(SEQ, concatForm(sqExpand(seqItems(v)))))
+	case ISeq:
+		items := seqItems(v)
+		if len(items) == 0 {
+			return RT.List(LIST)
+		}
+		return RT.List(SEQ, concatForm(sqExpand(items)))
+	default:
+		return form
+	}
+}
+
+// sqExpand turns a flat slice of forms into the arguments of a `concat` call:
+// plain forms become single-element `(list form)` calls, `~@x` forms splice
+// `x` in directly, and everything else is syntax-quoted first.
+func sqExpand(items []interface{}) []interface{} {
+	parts := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		switch {
+		case isUnquoteSplicing(item):
+			parts = append(parts, second(item))
+		case isUnquote(item):
+			parts = append(parts, RT.List(LIST, second(item)))
+		default:
+			parts = append(parts, RT.List(LIST, syntaxQuote(item)))
+		}
+	}
+	return parts
+}
+
+func concatForm(parts []interface{}) interface{} {
+	return RT.List(append([]interface{}{CONCAT}, parts...)...)
+}
+
+func seqItems(s Seqable) []interface{} {
+	var items []interface{}
+	for seq := s.Seq(); seq != nil; seq = seq.Next() {
+		items = append(items, seq.First())
+	}
+	return items
+}
+
+func isUnquote(form interface{}) bool {
+	return isTaggedSeq(form, UNQUOTE)
+}
+
+func isUnquoteSplicing(form interface{}) bool {
+	return isTaggedSeq(form, UNQUOTE_SPLICING)
+}
+
+func isTaggedSeq(form interface{}, tag *Symbol) bool {
+	seq, ok := form.(ISeq)
+	if !ok || seq == nil {
+		return false
+	}
+	return seq.First() == tag
+}
+
+func second(form interface{}) interface{} {
+	seq, ok := form.(ISeq)
+	if !ok {
+		return nil
+	}
+	next, ok := seq.Next().(ISeq)
+	if !ok || next == nil {
+		return nil
+	}
+	return next.First()
+}
+
+// resolveSyntaxQuoteSymbol resolves a symbol read inside a syntax-quote:
+// `x#` symbols are replaced with an auto-generated gensym (the same gensym
+// for every occurrence of `x#` within one syntax-quote), unqualified symbols
+// ending in or containing '.' are returned unchanged, and everything else is
+// resolved against the current namespace.
+func resolveSyntaxQuoteSymbol(sym *Symbol) *Symbol {
+	if sym.ns == "" && strings.HasSuffix(sym.name, "#") {
+		return registerGensym(sym)
+	}
+	if sym.ns == "" && strings.HasSuffix(sym.name, ".") {
+		return sym
+	}
+	if sym.ns == "" && strings.Contains(sym.name, ".") {
+		return sym
+	}
+	return Compiler.ResolveSymbol(sym)
+}
+
+func registerGensym(sym *Symbol) *Symbol {
+	env, ok := GENSYM_ENV.Deref().(IPersistentMap)
+	if !ok || env == nil {
+		panic("Gensym literal not in syntax-quote")
+	}
+	if existing, ok := env.ValAt(sym).(*Symbol); ok && existing != nil {
+		return existing
+	}
+	base := strings.TrimSuffix(sym.name, "#")
+	gensym := InternSymbol(fmt.Sprintf("%s__%d__auto__", base, rand.Int()))
+	GENSYM_ENV.Set(env.Assoc(sym, gensym))
+	return gensym
 }
 
 type UnquoteReader struct {
 	AFn
 }
 
-// TODO
 func (ur *UnquoteReader) Invoke(args ...interface{}) interface{} {
-	return nil
+	r, _, opts, pendingForms := unpackReaderArgs(args)
+	pendingForms = r.ensurePending(pendingForms)
+
+	ch, err := r.ReadRune()
+	if err == io.EOF {
+		panic(r.eofMsg("character"))
+	}
+
+	if ch == '@' {
+		form := r.Read(true, nil, rune(0), nil, true, opts, pendingForms)
+		return RT.List(UNQUOTE_SPLICING, form)
+	}
+
+	r.UnreadRune()
+	form := r.Read(true, nil, rune(0), nil, true, opts, pendingForms)
+	return RT.List(UNQUOTE, form)
 }
 
 /*
@@ -527,15 +1033,15 @@ type ListReader struct {
 
 func (lr *ListReader) Invoke(args ...interface{}) interface{} {
 	r, _, opts, pendingForms := unpackReaderArgs(args)
-	line := -1
-	column := -1
+	line, column := r.LineColumn()
+	column--
 	l := r.ReadDelimitedList(')', true, opts, r.ensurePending(pendingForms))
 	if len(l) == 0 {
 		return EMPTY_PERSISTENT_LIST
 	}
 	s := CreatePersistentListFromInterfaceSlice(l)
 	if line != -1 {
-		return s.WithMeta(RT.Map(LINE_KEY, line, COLUMN_KEY, column))
+		return s.WithMeta(RT.Map(LINE_KEY, line, COLUMN_KEY, column, FILE_KEY, Compiler.SourcePath()))
 	} else {
 		return s
 	}
@@ -556,30 +1062,48 @@ type VectorReader struct {
 
 func (vr *VectorReader) Invoke(args ...interface{}) interface{} {
 	r, _, opts, pendingForms := unpackReaderArgs(args)
-	return CreateLazilyPersistentVector(r.ReadDelimitedList(']', true, opts, r.ensurePending(pendingForms)))
+	line, column := r.LineColumn()
+	column--
+	v := CreateLazilyPersistentVector(r.ReadDelimitedList(']', true, opts, r.ensurePending(pendingForms)))
+	if line != -1 {
+		return v.WithMeta(RT.Map(LINE_KEY, line, COLUMN_KEY, column, FILE_KEY, Compiler.SourcePath()))
+	}
+	return v
 }
 
 type MapReader struct {
 	AFn
 }
 
-// TODO
 func (mr *MapReader) Invoke(args ...interface{}) interface{} {
 	r, _, opts, pendingForms := unpackReaderArgs(args)
+	line, column := r.LineColumn()
+	column--
 	a := r.ReadDelimitedList('}', true, opts, r.ensurePending(pendingForms))
 	if len(a) % 2 == 1 {
 		panic("Map literal must contain an even number of forms.")
 	}
-	return RT.Map(a...)
+	m := RT.Map(a...)
+	if line != -1 {
+		return m.WithMeta(RT.Map(LINE_KEY, line, COLUMN_KEY, column, FILE_KEY, Compiler.SourcePath()))
+	}
+	return m
 }
 
 type SetReader struct {
 	AFn
 }
 
-// TODO
 func (sr *SetReader) Invoke(args ...interface{}) interface{} {
-	return nil
+	r, _, opts, pendingForms := unpackReaderArgs(args)
+	line, column := r.LineColumn()
+	column--
+	a := r.ReadDelimitedList('}', true, opts, r.ensurePending(pendingForms))
+	s := CreatePersistentHashSetFromInterfaceSlice(a...)
+	if line != -1 {
+		return s.WithMeta(RT.Map(LINE_KEY, line, COLUMN_KEY, column, FILE_KEY, Compiler.SourcePath()))
+	}
+	return s
 }
 
 type UnmatchedDelimiterReader struct {
@@ -604,18 +1128,240 @@ type CtorReader struct {
 	AFn
 }
 
-// TODO
 func (cr *CtorReader) Invoke(args ...interface{}) interface{} {
+	r, _, opts, pendingForms := unpackReaderArgs(args)
+	pendingForms = r.ensurePending(pendingForms)
+
+	// DispatchReader already unread the dispatch rune before invoking us
+	// (it found no entry for it in the dispatch table), so read it fresh
+	// here rather than reusing the stale rune it passed in -- otherwise
+	// ReadToken would see that same rune twice and double it into the tag.
+	ch, err := r.ReadRune()
+	if err == io.EOF {
+		panic(r.eofMsg("character"))
+	}
+
+	token := r.ReadToken(ch)
+	tag, ok := matchSymbol(token).(*Symbol)
+	if !ok {
+		panic("Reader tag must be a symbol: " + token)
+	}
+
+	if tag.ns == "" && strings.Contains(tag.name, ".") {
+		panic("no ctor reader for class: " + token)
+	}
+
+	form := r.Read(true, nil, rune(0), nil, true, opts, pendingForms)
+
+	if reader := lookupDataReader(tag); reader != nil {
+		return reader.Invoke(form)
+	}
+
+	if defaultFn, ok := DEFAULT_DATA_READER_FN.Deref().(IFn); ok && defaultFn != nil {
+		return defaultFn.Invoke(tag, form)
+	}
+
+	return CreateTaggedLiteral(tag, form)
+}
+
+func lookupDataReader(tag *Symbol) IFn {
+	// *data-readers* takes precedence over the built-in #inst/#uuid readers,
+	// so callers can override them -- matching real Clojure's precedence.
+	if readers, ok := DATA_READERS.Deref().(IPersistentMap); ok && readers != nil {
+		if fn, ok := readers.ValAt(tag).(IFn); ok {
+			return fn
+		}
+	}
+	if fn, ok := defaultDataReaders[tag]; ok {
+		return fn
+	}
 	return nil
 }
 
+type instReader struct {
+	AFn
+}
+
+func (ir *instReader) Invoke(args ...interface{}) interface{} {
+	s, ok := args[0].(string)
+	if !ok {
+		panic("Instance literal expects a string for its timestamp")
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		panic("Invalid #inst: " + err.Error())
+	}
+	return t
+}
+
+type uuidReader struct {
+	AFn
+}
+
+func (ur *uuidReader) Invoke(args ...interface{}) interface{} {
+	s, ok := args[0].(string)
+	if !ok {
+		panic("UUID literal expects a string")
+	}
+	u, err := ParseUUID(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return u
+}
+
 type ConditionalReader struct {
 	AFn
 }
 
-// TODO
 func (cr *ConditionalReader) Invoke(args ...interface{}) interface{} {
-	return nil
+	r, _, opts, rawPendingForms := unpackReaderArgs(args)
+	topLevel := rawPendingForms == nil
+	pendingForms := r.ensurePending(rawPendingForms)
+
+	ch, err := r.ReadRune()
+	if err == io.EOF {
+		panic(r.eofMsg("character"))
+	}
+
+	splicing := ch == '@'
+	if splicing {
+		ch, err = r.ReadRune()
+		if err == io.EOF {
+			panic(r.eofMsg("character"))
+		}
+	}
+
+	if splicing && topLevel {
+		panic("Reader conditional splicing not allowed at the top level.")
+	}
+
+	if ch != '(' {
+		panic("read-cond body must be a list")
+	}
+
+	readCondOpt, _ := readerOpt(opts, OPT_READ_COND).(*Keyword)
+	if readCondOpt == nil {
+		// No :read-cond option supplied - the whole form is unreadable, so just
+		// discard it the same way DiscardReader discards a form. The '(' has
+		// already been consumed, so read through to the matching ')'.
+		r.ReadDelimitedList(')', true, opts, pendingForms)
+		return r
+	}
+
+	forms := r.ReadDelimitedList(')', true, opts, pendingForms)
+
+	if readCondOpt == COND_PRESERVE {
+		return &ReaderConditional{form: CreatePersistentListFromInterfaceSlice(forms), splicing: splicing}
+	}
+
+	if len(forms)%2 != 0 {
+		panic("read-cond requires an even number of forms")
+	}
+
+	features := readerOpt(opts, OPT_FEATURES)
+
+	var selected interface{}
+	found := false
+	seen := make(map[*Keyword]bool)
+
+	for i := 0; i < len(forms); i += 2 {
+		kw, ok := forms[i].(*Keyword)
+		if !ok {
+			panic(fmt.Sprintf("Feature should be a keyword: %v", forms[i]))
+		}
+		if seen[kw] {
+			panic(fmt.Sprintf("Duplicate feature: %v", kw))
+		}
+		seen[kw] = true
+
+		if !found && (kw == DEFAULT_FEATURE_KEY || featureSatisfied(features, kw)) {
+			selected = forms[i+1]
+			found = true
+		}
+	}
+
+	if !found {
+		return r
+	}
+
+	if splicing {
+		seq, ok := selected.(Seqable)
+		if !ok {
+			panic("Spliced form list in read-cond-splicing must extend Seqable")
+		}
+		pf := pendingForms.(*list.List)
+		for s := seq.Seq(); s != nil; s = s.Next() {
+			pf.PushBack(s.First())
+		}
+		return r
+	}
+
+	return selected
+}
+
+// readerOpt pulls a single value out of the reader options map, returning nil
+// if opts isn't a map or doesn't contain key.
+func readerOpt(opts interface{}, key *Keyword) interface{} {
+	m, ok := opts.(IPersistentMap)
+	if !ok || m == nil {
+		return nil
+	}
+	return m.ValAt(key)
+}
+
+// installPlatformFeature ensures PLATFORM_KEY is always present in the
+// :features set of opts, creating the set (and the opts map, if opts isn't
+// one) when opts carries none.
+func installPlatformFeature(opts interface{}) interface{} {
+	m, ok := opts.(IPersistentMap)
+	if !ok || m == nil {
+		// No reader options were supplied -- PLATFORM_KEY must always be
+		// installed so #?(:clj ...) can select the default platform branch,
+		// so build a fresh map to install it into instead of passing
+		// nil/opts through unchanged.
+		m = RT.Map()
+	}
+	existing := m.ValAt(OPT_FEATURES)
+	if featureSatisfied(existing, PLATFORM_KEY) {
+		return m
+	}
+	items := []interface{}{PLATFORM_KEY}
+	if s, ok := existing.(Seqable); ok && s != nil {
+		for seq := s.Seq(); seq != nil; seq = seq.Next() {
+			items = append(items, seq.First())
+		}
+	}
+	return m.Assoc(OPT_FEATURES, CreatePersistentHashSetFromInterfaceSlice(items...))
+}
+
+func featureSatisfied(features interface{}, kw *Keyword) bool {
+	s, ok := features.(Seqable)
+	if !ok || s == nil {
+		return false
+	}
+	for seq := s.Seq(); seq != nil; seq = seq.Next() {
+		if seq.First() == kw {
+			return true
+		}
+	}
+	return false
+}
+
+// ReaderConditional is the value produced when reading `#?`/`#?@` with
+// :read-cond :preserve - it carries the raw (unselected) form so that tools
+// like pr-str can round-trip it.
+type ReaderConditional struct {
+	form     interface{}
+	splicing bool
+}
+
+func (rc *ReaderConditional) GetForm() interface{} {
+	return rc.form
+}
+
+func (rc *ReaderConditional) IsSplicing() bool {
+	return rc.splicing
 }
 
 /*
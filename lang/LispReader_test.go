@@ -0,0 +1,387 @@
+package lang
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readTestNumber(s string) interface{} {
+	runes := []rune(s)
+	r := CreateLispReader(strings.NewReader(string(runes[1:])))
+	return r.ReadNumber(runes[0])
+}
+
+func TestReadNumber(t *testing.T) {
+	cases := []struct {
+		in   string
+		want interface{}
+	}{
+		{"0", 0},
+		{"42", 42},
+		{"-7", -7},
+		{"+7", 7},
+		{"0x1F", 31},
+		{"-0x10", -16},
+		{"017", 15},
+		{"2r1010", 10},
+		{"+2r1010", 10},
+		{"36rZ", 35},
+		{"3.14", 3.14},
+		{"1e3", 1000.0},
+		{"4/2", 2},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			got := readTestNumber(c.in)
+			if got != c.want {
+				t.Fatalf("readNumber(%q) = %#v, want %#v", c.in, got, c.want)
+			}
+		})
+	}
+
+	t.Run("22/7", func(t *testing.T) {
+		got, ok := readTestNumber("22/7").(*Ratio)
+		if !ok {
+			t.Fatalf("expected *Ratio, got %#v", readTestNumber("22/7"))
+		}
+		if got.val.Cmp(big.NewRat(22, 7)) != 0 {
+			t.Fatalf("got %s, want 22/7", got.String())
+		}
+	})
+
+	t.Run("N suffix produces BigInt", func(t *testing.T) {
+		got, ok := readTestNumber("9999999999999999999999N").(*BigInt)
+		if !ok {
+			t.Fatalf("expected *BigInt, got %#v", readTestNumber("9999999999999999999999N"))
+		}
+		want, _ := new(big.Int).SetString("9999999999999999999999", 10)
+		if got.val.Cmp(want) != 0 {
+			t.Fatalf("got %s, want %s", got.String(), want.String())
+		}
+	})
+
+	t.Run("M suffix produces BigDecimal", func(t *testing.T) {
+		if _, ok := readTestNumber("3.14M").(*BigDecimal); !ok {
+			t.Fatalf("expected *BigDecimal, got %#v", readTestNumber("3.14M"))
+		}
+	})
+
+	t.Run("invalid octal panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("expected panic for invalid number")
+			}
+		}()
+		readTestNumber("08")
+	})
+}
+
+func readForm(s string, opts interface{}) interface{} {
+	r := CreateLispReader(strings.NewReader(s))
+	return r.Read(true, nil, rune(0), nil, false, opts, nil)
+}
+
+func TestConditionalReader(t *testing.T) {
+	allowOpts := RT.Map(OPT_READ_COND, COND_ALLOW)
+
+	t.Run("selects the matching platform feature", func(t *testing.T) {
+		got := readForm(`#?(:clj :yes :default :no)`, allowOpts)
+		if got != InternKeywordByNsName("yes") {
+			t.Fatalf("got %#v, want :yes", got)
+		}
+	})
+
+	t.Run("falls back to :default when no feature matches", func(t *testing.T) {
+		got := readForm(`#?(:cljs :yes :default :no)`, allowOpts)
+		if got != InternKeywordByNsName("no") {
+			t.Fatalf("got %#v, want :no", got)
+		}
+	})
+
+	t.Run("discards the form when :read-cond isn't supplied", func(t *testing.T) {
+		got := readForm(`#?(:clj :yes) 42`, nil)
+		if got != 42 {
+			t.Fatalf("got %#v, want 42 after discard", got)
+		}
+	})
+
+	t.Run("splices a matched clause into the enclosing collection", func(t *testing.T) {
+		seq, ok := readForm(`[:a #?@(:clj [1 2 3]) :b]`, allowOpts).(Seqable)
+		if !ok {
+			t.Fatalf("expected a Seqable vector")
+		}
+		var items []interface{}
+		for s := seq.Seq(); s != nil; s = s.Next() {
+			items = append(items, s.First())
+		}
+		want := []interface{}{InternKeywordByNsName("a"), 1, 2, 3, InternKeywordByNsName("b")}
+		if len(items) != len(want) {
+			t.Fatalf("got %#v, want %#v", items, want)
+		}
+		for i := range want {
+			if items[i] != want[i] {
+				t.Fatalf("got %#v, want %#v", items, want)
+			}
+		}
+	})
+}
+
+// metaHaver is the reader's minimal view of IMeta, just enough to pull
+// :line/:column back off a form for these tests.
+type metaHaver interface {
+	Meta() IPersistentMap
+}
+
+func TestCollectionReaderColumnMetadata(t *testing.T) {
+	cases := []struct {
+		name   string
+		form   string
+		column int // 1-indexed column of the form's opening delimiter
+	}{
+		{"list", `(1 2 3)`, 1},
+		{"vector", `[1 2 3]`, 1},
+		{"map", `{:a 1}`, 1},
+		// "#{" is two runes -- the dispatch reader consumes '#' before
+		// SetReader ever sees '{', so the delimiter itself sits at column 2.
+		{"set", `#{1 2 3}`, 2},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mh, ok := readForm(c.form, nil).(metaHaver)
+			if !ok {
+				t.Fatalf("expected %q to carry metadata", c.form)
+			}
+			if col := mh.Meta().ValAt(COLUMN_KEY); col != c.column {
+				t.Fatalf("got column %#v, want %d", col, c.column)
+			}
+		})
+	}
+}
+
+func TestCtorReaderTaggedLiterals(t *testing.T) {
+	t.Run("#inst dispatches to the built-in instant reader", func(t *testing.T) {
+		got, ok := readForm(`#inst "2020-01-01T00:00:00Z"`, nil).(time.Time)
+		if !ok {
+			t.Fatalf("expected time.Time, got %#v", readForm(`#inst "2020-01-01T00:00:00Z"`, nil))
+		}
+		if got.Year() != 2020 {
+			t.Fatalf("got %v, want year 2020", got)
+		}
+	})
+
+	t.Run("#uuid dispatches to the built-in UUID reader", func(t *testing.T) {
+		want := "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+		got, ok := readForm(`#uuid "`+want+`"`, nil).(*UUID)
+		if !ok {
+			t.Fatalf("expected *UUID, got %#v", readForm(`#uuid "`+want+`"`, nil))
+		}
+		if got.String() != want {
+			t.Fatalf("got %s, want %s", got.String(), want)
+		}
+	})
+
+	t.Run("unknown tag produces a TaggedLiteral", func(t *testing.T) {
+		got, ok := readForm(`#my/thing [1 2]`, nil).(*TaggedLiteral)
+		if !ok {
+			t.Fatalf("expected *TaggedLiteral, got %#v", readForm(`#my/thing [1 2]`, nil))
+		}
+		if got.GetTag().name != "thing" || got.GetTag().ns != "my" {
+			t.Fatalf("got tag %#v, want my/thing", got.GetTag())
+		}
+	})
+}
+
+// seqSlice flattens an ISeq-producing form (as built by syntaxQuote) into a
+// plain slice so tests can index into it without re-implementing ISeq walks.
+func seqSlice(form interface{}) []interface{} {
+	seq, ok := form.(ISeq)
+	if !ok || seq == nil {
+		return nil
+	}
+	var out []interface{}
+	for s := seq; s != nil; s = s.Next() {
+		out = append(out, s.First())
+	}
+	return out
+}
+
+func TestSyntaxQuoteGensym(t *testing.T) {
+	t.Run("same x# resolves to the same gensym within one syntax-quote", func(t *testing.T) {
+		// `(a# a#) -> (seq (concat (list (quote g)) (list (quote g))))
+		top := seqSlice(readForm("`(a# a#)", nil))
+		if len(top) != 2 {
+			t.Fatalf("expected (seq concat-form), got %#v", top)
+		}
+		concat := seqSlice(top[1])
+		if len(concat) != 3 {
+			t.Fatalf("expected (concat part1 part2), got %#v", concat)
+		}
+		part1 := seqSlice(concat[1])
+		part2 := seqSlice(concat[2])
+		if len(part1) != 2 || len(part2) != 2 {
+			t.Fatalf("expected (list (quote sym)) parts, got %#v and %#v", part1, part2)
+		}
+		sym1, ok1 := seqSlice(part1[1])[1].(*Symbol)
+		sym2, ok2 := seqSlice(part2[1])[1].(*Symbol)
+		if !ok1 || !ok2 {
+			t.Fatalf("expected quoted symbols, got %#v and %#v", part1[1], part2[1])
+		}
+		if sym1 != sym2 {
+			t.Fatalf("got different gensyms %v and %v for repeated a#", sym1, sym2)
+		}
+		if !strings.HasPrefix(sym1.name, "a__") || !strings.HasSuffix(sym1.name, "__auto__") {
+			t.Fatalf("got gensym name %q, want a__<n>__auto__", sym1.name)
+		}
+	})
+
+	t.Run("unquote-splicing uses the canonical clojure.core/unquote-splicing symbol", func(t *testing.T) {
+		if UNQUOTE_SPLICING.ns != "clojure.core" || UNQUOTE_SPLICING.name != "unquote-splicing" {
+			t.Fatalf("got %s/%s, want clojure.core/unquote-splicing", UNQUOTE_SPLICING.ns, UNQUOTE_SPLICING.name)
+		}
+
+		// `(~@xs) -> (seq (concat xs))
+		top := seqSlice(readForm("`(~@xs)", nil))
+		concat := seqSlice(top[1])
+		if len(concat) != 2 {
+			t.Fatalf("expected (concat xs), got %#v", concat)
+		}
+		sym, ok := concat[1].(*Symbol)
+		if !ok || sym.name != "xs" {
+			t.Fatalf("expected unquote-spliced symbol xs, got %#v", concat[1])
+		}
+	})
+}
+
+func TestLineNumberingPushbackReaderEOF(t *testing.T) {
+	t.Run("UnreadRune fails after a real EOF instead of resurrecting the last rune", func(t *testing.T) {
+		r := CreateLineNumberingPushbackReader(strings.NewReader("a"))
+		ch, _, err := r.ReadRune()
+		if err != nil || ch != 'a' {
+			t.Fatalf("got %q, %v, want 'a', nil", ch, err)
+		}
+		if _, _, err := r.ReadRune(); err == nil {
+			t.Fatalf("expected EOF on the second read")
+		}
+		if err := r.UnreadRune(); err == nil {
+			t.Fatalf("expected UnreadRune to fail after a failed ReadRune")
+		}
+		if _, _, err := r.ReadRune(); err == nil {
+			t.Fatalf("expected ReadRune to keep reporting EOF instead of resurrecting 'a'")
+		}
+	})
+
+	t.Run("unterminated list hits real EOF instead of looping forever", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("expected a panic for EOF while reading a list")
+			}
+		}()
+		CreateLispReader(strings.NewReader("1 2")).ReadDelimitedList(')', false, nil, nil)
+	})
+}
+
+// fnFormParts reads `#(...)`  and splits the resulting (fn* [params...] body)
+// into its parameter symbols and its single body form.
+func fnFormParts(t *testing.T, s string) (params []interface{}, body []interface{}) {
+	t.Helper()
+	top := seqSlice(readForm(s, nil))
+	if len(top) != 3 || top[0] != FN_STAR {
+		t.Fatalf("expected (fn* [params] body), got %#v", top)
+	}
+	vec, ok := top[1].(Seqable)
+	if !ok {
+		t.Fatalf("expected a param vector, got %#v", top[1])
+	}
+	return seqSlice(vec.Seq()), seqSlice(top[2])
+}
+
+func TestFnReader(t *testing.T) {
+	t.Run("multi-form body is wrapped as a single body form", func(t *testing.T) {
+		params, body := fnFormParts(t, `#(+ % 1)`)
+		if len(params) != 1 {
+			t.Fatalf("expected one param, got %#v", params)
+		}
+		if len(body) != 3 {
+			t.Fatalf("expected (+ p 1) as a single body form, got %#v", body)
+		}
+		if body[1] != params[0] {
+			t.Fatalf("got %#v, want the body to reference the param symbol %#v", body[1], params[0])
+		}
+	})
+
+	t.Run("a bare % body calls the arg instead of returning it", func(t *testing.T) {
+		params, body := fnFormParts(t, `#(%)`)
+		if len(body) != 1 || body[0] != params[0] {
+			t.Fatalf("expected ((p)), got body %#v with params %#v", body, params)
+		}
+	})
+
+	t.Run("%& collects the rest arg", func(t *testing.T) {
+		params, body := fnFormParts(t, `#(apply + %&)`)
+		if len(params) != 2 || params[0] != AMP {
+			t.Fatalf("expected [& rest], got %#v", params)
+		}
+		if body[2] != params[1] {
+			t.Fatalf("got %#v, want the body to reference the rest symbol %#v", body[2], params[1])
+		}
+	})
+
+	t.Run("skipped indices synthesize a placeholder and reused indices share one gensym", func(t *testing.T) {
+		params, body := fnFormParts(t, `#(vector %1 %1 %3)`)
+		if len(params) != 3 {
+			t.Fatalf("expected 3 params for %%1 and %%3, got %#v", params)
+		}
+		if body[1] != params[0] || body[2] != params[0] {
+			t.Fatalf("expected both %%1 occurrences to share one gensym, got body %#v params %#v", body, params)
+		}
+		if body[3] != params[2] {
+			t.Fatalf("got %#v, want the third body form to reference %%3's param %#v", body[3], params[2])
+		}
+		if params[1] == params[0] || params[1] == params[2] {
+			t.Fatalf("expected the synthesized %%2 placeholder to be distinct, got %#v", params)
+		}
+	})
+
+	t.Run("nested #(...) panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("expected a panic for nested #()")
+			}
+		}()
+		readForm(`#(#(%))`, nil)
+	})
+}
+
+type overrideInstReader struct {
+	AFn
+}
+
+func (or *overrideInstReader) Invoke(args ...interface{}) interface{} {
+	return "overridden: " + args[0].(string)
+}
+
+func TestLookupDataReaderPrecedence(t *testing.T) {
+	t.Run("*data-readers* overrides the built-in #inst reader", func(t *testing.T) {
+		readers := RT.Map(INST_READER_SYM, &overrideInstReader{})
+		PushThreadBindings(RT.Map(DATA_READERS, readers))
+		defer PopThreadBindings()
+
+		got := readForm(`#inst "2020-01-01T00:00:00Z"`, nil)
+		if got != "overridden: 2020-01-01T00:00:00Z" {
+			t.Fatalf("got %#v, want the *data-readers* override to win over the built-in reader", got)
+		}
+	})
+
+	t.Run("falls back to the built-in reader when *data-readers* has no entry", func(t *testing.T) {
+		PushThreadBindings(RT.Map(DATA_READERS, RT.Map()))
+		defer PopThreadBindings()
+
+		if _, ok := readForm(`#inst "2020-01-01T00:00:00Z"`, nil).(time.Time); !ok {
+			t.Fatalf("expected the built-in #inst reader to still apply")
+		}
+	})
+}
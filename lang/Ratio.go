@@ -0,0 +1,58 @@
+package lang
+
+import "math/big"
+
+// Ratio backs Clojure's exact-fraction literals (`22/7`) that don't reduce
+// to a whole number.
+type Ratio struct {
+	val *big.Rat
+}
+
+func CreateRatio(v *big.Rat) *Ratio {
+	return &Ratio{val: v}
+}
+
+func (r *Ratio) String() string {
+	return r.val.RatString()
+}
+
+func (r *Ratio) Numerator() *big.Int {
+	return r.val.Num()
+}
+
+func (r *Ratio) Denominator() *big.Int {
+	return r.val.Denom()
+}
+
+func (r *Ratio) Float64() float64 {
+	f, _ := r.val.Float64()
+	return f
+}
+
+func (r *Ratio) Add(o *Ratio) *Ratio {
+	return CreateRatio(new(big.Rat).Add(r.val, o.val))
+}
+
+func (r *Ratio) Sub(o *Ratio) *Ratio {
+	return CreateRatio(new(big.Rat).Sub(r.val, o.val))
+}
+
+func (r *Ratio) Mul(o *Ratio) *Ratio {
+	return CreateRatio(new(big.Rat).Mul(r.val, o.val))
+}
+
+func (r *Ratio) Div(o *Ratio) *Ratio {
+	return CreateRatio(new(big.Rat).Quo(r.val, o.val))
+}
+
+func (r *Ratio) Neg() *Ratio {
+	return CreateRatio(new(big.Rat).Neg(r.val))
+}
+
+func (r *Ratio) Equals(o interface{}) bool {
+	other, ok := o.(*Ratio)
+	if !ok {
+		return false
+	}
+	return r.val.Cmp(other.val) == 0
+}
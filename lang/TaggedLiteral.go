@@ -0,0 +1,45 @@
+package lang
+
+// TaggedLiteral is the value produced for a `#tag form` reader literal whose
+// tag has no registered data-reader: it preserves the tag and the form
+// so that callers (e.g. pr-str, or a later pass with its own *data-readers*)
+// can still round-trip it.
+type TaggedLiteral struct {
+	tag  *Symbol
+	form interface{}
+}
+
+func CreateTaggedLiteral(tag *Symbol, form interface{}) *TaggedLiteral {
+	return &TaggedLiteral{tag: tag, form: form}
+}
+
+func (tl *TaggedLiteral) GetTag() *Symbol {
+	return tl.tag
+}
+
+func (tl *TaggedLiteral) GetForm() interface{} {
+	return tl.form
+}
+
+func (tl *TaggedLiteral) ValAt(key interface{}) interface{} {
+	return tl.ValAtDefault(key, nil)
+}
+
+func (tl *TaggedLiteral) ValAtDefault(key interface{}, notFound interface{}) interface{} {
+	switch key {
+	case TAG_KEY:
+		return tl.tag
+	case FORM_KEY:
+		return tl.form
+	default:
+		return notFound
+	}
+}
+
+func (tl *TaggedLiteral) Equals(o interface{}) bool {
+	other, ok := o.(*TaggedLiteral)
+	if !ok {
+		return false
+	}
+	return tl.tag == other.tag && tl.form == other.form
+}
@@ -0,0 +1,52 @@
+package lang
+
+import (
+	"fmt"
+)
+
+// UUID is a minimal RFC 4122 value, used to back the `#uuid` reader literal.
+type UUID struct {
+	bytes [16]byte
+}
+
+func CreateUUID(bytes [16]byte) *UUID {
+	return &UUID{bytes: bytes}
+}
+
+func (u *UUID) String() string {
+	b := u.bytes
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func (u *UUID) Equals(o interface{}) bool {
+	other, ok := o.(*UUID)
+	if !ok {
+		return false
+	}
+	return u.bytes == other.bytes
+}
+
+// ParseUUID parses the canonical 8-4-4-4-12 hex-digit UUID representation.
+func ParseUUID(s string) (*UUID, error) {
+	var hex string
+	for _, r := range s {
+		if r == '-' {
+			continue
+		}
+		hex += string(r)
+	}
+	if len(hex) != 32 {
+		return nil, fmt.Errorf("invalid UUID string: %s", s)
+	}
+
+	var bytes [16]byte
+	for i := 0; i < 16; i++ {
+		var b byte
+		_, err := fmt.Sscanf(hex[i*2:i*2+2], "%02x", &b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid UUID string: %s", s)
+		}
+		bytes[i] = b
+	}
+	return &UUID{bytes: bytes}, nil
+}